@@ -0,0 +1,412 @@
+//go:build linux
+
+// collector/cgroup_collector.go
+package collector
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tklauser/go-sysconf"
+
+	cfg "ArgusSentinel/config"
+	"ArgusSentinel/types"
+)
+
+const (
+	cgroupRoot = "/sys/fs/cgroup"
+	procRoot   = "/proc"
+)
+
+/*
+* cgroupSample struct
+* A point-in-time reading of a cgroup's accounting files, kept around so
+* the next poll can compute CPU% from the delta
+ */
+type cgroupSample struct {
+	cpuUserTicks uint64
+	cpuSysTicks  uint64
+	timestamp    time.Time
+}
+
+/*
+* CgroupCollector struct
+* Reads /sys/fs/cgroup (v1 or v2) to attribute a process to its container
+* and surface per-cgroup memory/CPU/IO stats alongside the per-process ones
+ */
+type CgroupCollector struct {
+	config       *cfg.MonitoringConfig
+	filter       *cfg.ProcessFilter
+	mutex        sync.Mutex
+	previous     map[string]cgroupSample  // keyed by cgroup path, updated once per cgroup per poll
+	polled       map[string]cgroupCPUStat // cpuDelta results already computed this poll, keyed by cgroup path
+	clockTicksHz float64
+	isV2         bool
+}
+
+/*
+* Create a new CgroupCollector
+ */
+func NewCgroupCollector(config *cfg.MonitoringConfig) *CgroupCollector {
+	clockTicksHz := float64(100)
+	if ticks, err := sysconf.Sysconf(sysconf.SC_CLK_TCK); err == nil && ticks > 0 {
+		clockTicksHz = float64(ticks)
+	}
+
+	return &CgroupCollector{
+		config:       config,
+		filter:       cfg.NewProcessFilter(config),
+		previous:     make(map[string]cgroupSample),
+		polled:       make(map[string]cgroupCPUStat),
+		clockTicksHz: clockTicksHz,
+		isV2:         isCgroupV2(),
+	}
+}
+
+/*
+* CgroupCollector BeginPoll method
+* Clears the per-poll CPU result cache so the next Enrich call for each
+* cgroup takes a fresh sample; see cpuDelta.
+ */
+func (cc *CgroupCollector) BeginPoll() {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.polled = make(map[string]cgroupCPUStat)
+}
+
+func isCgroupV2() bool {
+	_, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	return err == nil
+}
+
+func newCgroupEnricher(config *cfg.MonitoringConfig) cgroupEnricher {
+	return NewCgroupCollector(config)
+}
+
+/*
+* CgroupCollector Enrich method
+* Fills in the cgroup-derived fields of info for the given pid. It leaves
+* info unmodified when the process has no resolvable cgroup. CgroupPath
+* is set whenever one resolves, even if IncludedCgroups/ExcludedCgroups
+* would exclude it, so ProcessFilter.ShouldMonitorProcess can see it and
+* actually drop the process - only the (comparatively expensive) stat
+* file reads are skipped for excluded cgroups.
+ */
+func (cc *CgroupCollector) Enrich(pid int32, info types.ProcessInfo) (types.ProcessInfo, error) {
+	cgroupPath, err := cc.resolveCgroupPath(pid)
+	if err != nil || cgroupPath == "" {
+		return info, err
+	}
+
+	info.CgroupPath = cgroupPath
+	info.ContainerID = containerIDFromCgroupPath(cgroupPath)
+
+	if !cc.filter.ShouldMonitorCgroup(cgroupPath) {
+		return info, nil
+	}
+
+	var stats cgroupStats
+	if cc.isV2 {
+		stats, err = cc.readV2Stats(cgroupPath)
+	} else {
+		stats, err = cc.readV1Stats(cgroupPath)
+	}
+	if err != nil {
+		return info, fmt.Errorf("reading cgroup stats for %q: %w", cgroupPath, err)
+	}
+
+	info.RSS = stats.rss
+	info.Cache = stats.cache
+	info.PgMajFault = stats.pgMajFault
+	info.SwapBytes = stats.swapBytes
+	info.IOReadBytes = stats.ioReadBytes
+	info.IOWriteBytes = stats.ioWriteBytes
+	info.PidsCurrent = stats.pidsCurrent
+
+	userNanos, sysNanos, cpuPercent := cc.cpuDelta(cgroupPath, stats.cpuUserTicks, stats.cpuSysTicks)
+	info.CPUUser = userNanos
+	info.CPUSys = sysNanos
+	info.CgroupCPUPercent = cpuPercent
+
+	return info, nil
+}
+
+/*
+* resolveCgroupPath finds the cgroup a pid belongs to by reading
+* /proc/<pid>/cgroup. For v1 it prefers the memory controller's path; for
+* v2 there is a single unified entry.
+ */
+func (cc *CgroupCollector) resolveCgroupPath(pid int32) (string, error) {
+	file, err := os.Open(filepath.Join(procRoot, strconv.Itoa(int(pid)), "cgroup"))
+	if err != nil {
+		return "", nil // process may have exited or have no cgroup entry, not fatal
+	}
+	defer file.Close()
+
+	var fallback string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		controllers, path := parts[1], parts[2]
+		if cc.isV2 || controllers == "memory" {
+			return path, nil
+		}
+		if fallback == "" {
+			fallback = path
+		}
+	}
+
+	return fallback, scanner.Err()
+}
+
+func containerIDFromCgroupPath(cgroupPath string) string {
+	segment := filepath.Base(cgroupPath)
+	if len(segment) == 64 {
+		// Long hex container IDs (Docker/containerd) are used as-is
+		return segment
+	}
+	return ""
+}
+
+/*
+* cgroupStats struct
+* Normalized stat fields, independent of the v1/v2 layout they came from
+ */
+type cgroupStats struct {
+	rss          uint64
+	cache        uint64
+	pgMajFault   uint64
+	swapBytes    uint64
+	cpuUserTicks uint64
+	cpuSysTicks  uint64
+	ioReadBytes  uint64
+	ioWriteBytes uint64
+	pidsCurrent  uint64
+}
+
+func (cc *CgroupCollector) readV1Stats(cgroupPath string) (cgroupStats, error) {
+	var stats cgroupStats
+
+	memStat, err := readKeyValueFile(filepath.Join(cgroupRoot, "memory", cgroupPath, "memory.stat"))
+	if err == nil {
+		stats.rss = memStat["rss"]
+		stats.cache = memStat["cache"]
+		stats.pgMajFault = memStat["pgmajfault"]
+		stats.swapBytes = memStat["swap"]
+	}
+
+	cpuStat, err := readKeyValueFile(filepath.Join(cgroupRoot, "cpuacct", cgroupPath, "cpuacct.stat"))
+	if err == nil {
+		stats.cpuUserTicks = cpuStat["user"]
+		stats.cpuSysTicks = cpuStat["system"]
+	}
+
+	stats.ioReadBytes, stats.ioWriteBytes = readV1IOServiceBytes(filepath.Join(cgroupRoot, "blkio", cgroupPath, "blkio.throttle.io_service_bytes"))
+
+	if pidsCurrent, err := readSingleValueFile(filepath.Join(cgroupRoot, "pids", cgroupPath, "pids.current")); err == nil {
+		stats.pidsCurrent = pidsCurrent
+	}
+
+	return stats, nil
+}
+
+func (cc *CgroupCollector) readV2Stats(cgroupPath string) (cgroupStats, error) {
+	var stats cgroupStats
+
+	memStat, err := readKeyValueFile(filepath.Join(cgroupRoot, cgroupPath, "memory.stat"))
+	if err == nil {
+		stats.rss = memStat["anon"]
+		stats.cache = memStat["file"]
+		stats.pgMajFault = memStat["pgmajfault"]
+	}
+
+	if swapBytes, err := readSingleValueFile(filepath.Join(cgroupRoot, cgroupPath, "memory.swap.current")); err == nil {
+		stats.swapBytes = swapBytes
+	}
+
+	cpuStat, err := readKeyValueFile(filepath.Join(cgroupRoot, cgroupPath, "cpu.stat"))
+	if err == nil {
+		// cpu.stat reports usec, convert to the same "ticks" unit used by v1
+		// so cpuDelta can treat both layouts uniformly.
+		stats.cpuUserTicks = microsecondsToTicks(cpuStat["user_usec"], cc.clockTicksHz)
+		stats.cpuSysTicks = microsecondsToTicks(cpuStat["system_usec"], cc.clockTicksHz)
+	}
+
+	stats.ioReadBytes, stats.ioWriteBytes = readV2IOStat(filepath.Join(cgroupRoot, cgroupPath, "io.stat"))
+
+	if pidsCurrent, err := readSingleValueFile(filepath.Join(cgroupRoot, cgroupPath, "pids.current")); err == nil {
+		stats.pidsCurrent = pidsCurrent
+	}
+
+	return stats, nil
+}
+
+/*
+* readV2IOStat sums rbytes/wbytes across every per-device line of a v2
+* io.stat file, e.g. "8:0 rbytes=1234 wbytes=5678 rios=1 wios=1 ...".
+* Missing or unreadable files are treated as zero, same as the other
+* per-cgroup stat readers.
+ */
+func readV2IOStat(path string) (readBytes, writeBytes uint64) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		for _, field := range strings.Fields(scanner.Text()) {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				readBytes += n
+			case "wbytes":
+				writeBytes += n
+			}
+		}
+	}
+
+	return readBytes, writeBytes
+}
+
+/*
+* readV1IOServiceBytes sums the "Read"/"Write" lines of a v1
+* blkio.throttle.io_service_bytes file, e.g. "8:0 Read 1234", across all
+* devices, ignoring the per-device "Total" lines.
+ */
+func readV1IOServiceBytes(path string) (readBytes, writeBytes uint64) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		n, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch fields[1] {
+		case "Read":
+			readBytes += n
+		case "Write":
+			writeBytes += n
+		}
+	}
+
+	return readBytes, writeBytes
+}
+
+func microsecondsToTicks(usec uint64, clockTicksHz float64) uint64 {
+	return uint64(float64(usec) / 1e6 * clockTicksHz)
+}
+
+/*
+* cgroupCPUStat struct
+* A cpuDelta result memoized for the remainder of the current poll -
+* every process in the same cgroup shares it instead of each diffing
+* against the sample the previous process in that cgroup just wrote
+ */
+type cgroupCPUStat struct {
+	userNanos  uint64
+	sysNanos   uint64
+	cpuPercent float64
+}
+
+/*
+* cpuDelta computes nanoseconds of user/sys CPU time consumed since the
+* last poll's sample for this cgroup. cpuPercent is (userNanos+sysNanos)
+* as a percentage of wall-clock time elapsed since that sample, i.e. 100%
+* means the cgroup kept one core fully busy over the interval - the same
+* convention gopsutil uses for CPUPercent.
+*
+* Enrich runs once per monitored pid, so every process sharing a cgroup
+* calls this in the same poll; only the first one actually takes a new
+* sample and advances cc.previous, the rest reuse that result from
+* cc.polled (cleared by BeginPoll at the start of each poll) so the
+* per-cgroup value doesn't depend on map-iteration order.
+ */
+func (cc *CgroupCollector) cpuDelta(cgroupPath string, userTicks, sysTicks uint64) (userNanos, sysNanos uint64, cpuPercent float64) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	if stat, ok := cc.polled[cgroupPath]; ok {
+		return stat.userNanos, stat.sysNanos, stat.cpuPercent
+	}
+
+	now := time.Now()
+	prev, ok := cc.previous[cgroupPath]
+	cc.previous[cgroupPath] = cgroupSample{cpuUserTicks: userTicks, cpuSysTicks: sysTicks, timestamp: now}
+
+	if ok && userTicks >= prev.cpuUserTicks && sysTicks >= prev.cpuSysTicks {
+		nanosPerTick := uint64(1e9 / cc.clockTicksHz)
+		userNanos = (userTicks - prev.cpuUserTicks) * nanosPerTick
+		sysNanos = (sysTicks - prev.cpuSysTicks) * nanosPerTick
+
+		if wallNanos := now.Sub(prev.timestamp).Nanoseconds(); wallNanos > 0 {
+			cpuPercent = float64(userNanos+sysNanos) / float64(wallNanos) * 100
+		}
+	}
+
+	cc.polled[cgroupPath] = cgroupCPUStat{userNanos: userNanos, sysNanos: sysNanos, cpuPercent: cpuPercent}
+	return userNanos, sysNanos, cpuPercent
+}
+
+func readKeyValueFile(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[fields[0]] = value
+	}
+
+	return values, scanner.Err()
+}
+
+func readSingleValueFile(path string) (uint64, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+}
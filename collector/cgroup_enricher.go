@@ -0,0 +1,19 @@
+// collector/cgroup_enricher.go
+package collector
+
+import "ArgusSentinel/types"
+
+/*
+* cgroupEnricher interface
+* Fills in the cgroup/container fields on a ProcessInfo. The real
+* implementation (CgroupCollector) only exists on Linux; other platforms
+* get a no-op via newCgroupEnricher so ProcessCollector stays portable.
+ */
+type cgroupEnricher interface {
+	Enrich(pid int32, info types.ProcessInfo) (types.ProcessInfo, error)
+
+	// BeginPoll marks the start of a new poll cycle, so per-cgroup CPU
+	// accounting is sampled once per cgroup per poll rather than once per
+	// process - see CgroupCollector.cpuDelta.
+	BeginPoll()
+}
@@ -0,0 +1,25 @@
+//go:build !linux
+
+// collector/cgroup_other.go
+package collector
+
+import (
+	cfg "ArgusSentinel/config"
+	"ArgusSentinel/types"
+)
+
+/*
+* noopCgroupEnricher struct
+* Stands in for CgroupCollector on platforms without /sys/fs/cgroup
+ */
+type noopCgroupEnricher struct{}
+
+func (noopCgroupEnricher) Enrich(pid int32, info types.ProcessInfo) (types.ProcessInfo, error) {
+	return info, nil
+}
+
+func (noopCgroupEnricher) BeginPoll() {}
+
+func newCgroupEnricher(config *cfg.MonitoringConfig) cgroupEnricher {
+	return noopCgroupEnricher{}
+}
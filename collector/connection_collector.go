@@ -0,0 +1,258 @@
+// collector/connection_collector.go
+package collector
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	gopsnet "github.com/shirou/gopsutil/v3/net"
+
+	cfg "ArgusSentinel/config"
+	"ArgusSentinel/types"
+)
+
+const (
+	dnsLookupTimeout = 500 * time.Millisecond
+	dnsCacheSize     = 1024
+)
+
+/*
+* ConnectionCollector struct
+* Lists a process' open sockets via gopsutil's net.ConnectionsPid and
+* turns them into types.NetworkConnection. The owning username and the
+* remote host's reverse-DNS name are both comparatively expensive next
+* to listing one more process' connections, so both are cached across
+* polls: usernames are few and never change, hostnames are bounded by an
+* LRU since a process can talk to an unbounded number of remote IPs.
+ */
+type ConnectionCollector struct {
+	config *cfg.MonitoringConfig
+
+	mutex     sync.Mutex
+	usernames map[int32]string
+	hostnames *lruCache
+}
+
+/*
+* Create a new ConnectionCollector
+ */
+func NewConnectionCollector(config *cfg.MonitoringConfig) *ConnectionCollector {
+	return &ConnectionCollector{
+		config:    config,
+		usernames: make(map[int32]string),
+		hostnames: newLRUCache(dnsCacheSize),
+	}
+}
+
+/*
+* ConnectionCollector Collect method
+* Returns pid's currently open connections, capped at
+* config.MaxConnectionsPerProcess
+ */
+func (cc *ConnectionCollector) Collect(pid int32) ([]types.NetworkConnection, error) {
+	stats, err := gopsnet.ConnectionsPid("all", pid)
+	if err != nil {
+		return nil, fmt.Errorf("listing connections for pid %d: %w", pid, err)
+	}
+
+	if max := int(cc.config.MaxConnectionsPerProcess); max > 0 && len(stats) > max {
+		stats = stats[:max]
+	}
+
+	conns := make([]types.NetworkConnection, 0, len(stats))
+	for _, stat := range stats {
+		conns = append(conns, types.NetworkConnection{
+			LocalIP:    stat.Laddr.IP,
+			LocalPort:  uint16(stat.Laddr.Port),
+			RemoteIP:   stat.Raddr.IP,
+			RemotePort: uint16(stat.Raddr.Port),
+			RemoteHost: cc.resolveHostname(stat.Raddr.IP),
+			Status:     stat.Status,
+			Protocol:   protocolName(stat.Type),
+			Username:   cc.resolveUsername(stat.Uids),
+			ProcessID:  pid,
+			CreateTime: time.Now(),
+		})
+	}
+
+	return conns, nil
+}
+
+func protocolName(socketType uint32) string {
+	switch socketType {
+	case syscall.SOCK_STREAM:
+		return "TCP"
+	case syscall.SOCK_DGRAM:
+		return "UDP"
+	default:
+		return "unknown"
+	}
+}
+
+func (cc *ConnectionCollector) resolveUsername(uids []int32) string {
+	if len(uids) == 0 {
+		return ""
+	}
+	uid := uids[0]
+
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	if name, ok := cc.usernames[uid]; ok {
+		return name
+	}
+
+	name := ""
+	if u, err := user.LookupId(strconv.Itoa(int(uid))); err == nil {
+		name = u.Username
+	}
+	cc.usernames[uid] = name
+	return name
+}
+
+func (cc *ConnectionCollector) resolveHostname(ip string) string {
+	if !cc.config.ResolveConnectionHostnames || ip == "" {
+		return ""
+	}
+
+	if host, ok := cc.hostnames.Get(ip); ok {
+		return host
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	host := ""
+	if names, err := (&net.Resolver{}).LookupAddr(ctx, ip); err == nil && len(names) > 0 {
+		host = strings.TrimSuffix(names[0], ".")
+	}
+
+	cc.hostnames.Put(ip, host)
+	return host
+}
+
+/*
+* diffConnections compares two connection snapshots for the same process
+* and returns a ProcessModification for each connection that appeared
+* (ConnectionOpened) or disappeared (ConnectionClosed) between them
+ */
+func diffConnections(old, new []types.NetworkConnection) []types.ProcessModification {
+	oldByTuple := connectionsByTuple(old)
+	newByTuple := connectionsByTuple(new)
+	timestamp := time.Now()
+
+	var modifications []types.ProcessModification
+	for tuple, conn := range newByTuple {
+		if _, exists := oldByTuple[tuple]; !exists {
+			modifications = append(modifications, types.ProcessModification{
+				Timestamp:   timestamp,
+				ProcessID:   conn.ProcessID,
+				ModType:     types.ConnectionOpened,
+				NewValue:    conn,
+				Description: fmt.Sprintf("Connection opened: %s -> %s:%d (%s)", conn.Protocol, conn.RemoteIP, conn.RemotePort, conn.Status),
+			})
+		}
+	}
+
+	for tuple, conn := range oldByTuple {
+		if _, exists := newByTuple[tuple]; !exists {
+			modifications = append(modifications, types.ProcessModification{
+				Timestamp:   timestamp,
+				ProcessID:   conn.ProcessID,
+				ModType:     types.ConnectionClosed,
+				OldValue:    conn,
+				Description: fmt.Sprintf("Connection closed: %s -> %s:%d", conn.Protocol, conn.RemoteIP, conn.RemotePort),
+			})
+		}
+	}
+
+	return modifications
+}
+
+type connectionTuple struct {
+	localIP, remoteIP     string
+	localPort, remotePort uint16
+	protocol              string
+}
+
+func connectionsByTuple(conns []types.NetworkConnection) map[connectionTuple]types.NetworkConnection {
+	byTuple := make(map[connectionTuple]types.NetworkConnection, len(conns))
+	for _, conn := range conns {
+		tuple := connectionTuple{
+			localIP:    conn.LocalIP,
+			localPort:  conn.LocalPort,
+			remoteIP:   conn.RemoteIP,
+			remotePort: conn.RemotePort,
+			protocol:   conn.Protocol,
+		}
+		byTuple[tuple] = conn
+	}
+	return byTuple
+}
+
+/*
+* lruCache struct
+* A small fixed-size string->string LRU, used to bound the reverse-DNS
+* cache so a process that talks to many distinct remote IPs can't grow
+* it unbounded
+ */
+type lruCache struct {
+	capacity int
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+type lruEntry struct {
+	key   string
+	value string
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *lruCache) Get(key string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) Put(key, value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
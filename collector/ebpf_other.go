@@ -0,0 +1,33 @@
+//go:build !linux || !ebpf
+
+// collector/ebpf_other.go
+package collector
+
+import (
+	"context"
+	"errors"
+
+	cfg "ArgusSentinel/config"
+	"ArgusSentinel/types"
+)
+
+/*
+* noopEbpfSource struct
+* Stands in for EbpfCollector on non-Linux platforms, and on Linux builds
+* that don't pass the "ebpf" tag (the real source needs bpf2go-generated
+* bindings, see ebpf_linux.go). Always reports itself unavailable so
+* ProcessCollector falls back to polling.
+ */
+type noopEbpfSource struct{}
+
+func (noopEbpfSource) Available() error {
+	return errors.New("ebpf collector not built into this binary, build linux with -tags ebpf")
+}
+
+func (noopEbpfSource) Monitor(ctx context.Context, onCreate func(types.ProcessInfo)) error {
+	return errors.New("ebpf collector not built into this binary")
+}
+
+func newEbpfSource(events chan<- types.ProcessEvent, config *cfg.MonitoringConfig) ebpfSource {
+	return noopEbpfSource{}
+}
@@ -0,0 +1,28 @@
+// collector/ebpf_source.go
+package collector
+
+import (
+	"context"
+
+	"ArgusSentinel/types"
+)
+
+/*
+* ebpfSource interface
+* Real-time process lifecycle source backed by eBPF tracepoints
+* (sched_process_exec/fork/exit). The real implementation (EbpfCollector)
+* only exists on Linux built with the "ebpf" tag; other builds get
+* noopEbpfSource via newEbpfSource so ProcessCollector stays portable.
+ */
+type ebpfSource interface {
+	// Available reports whether this process/kernel can attach the
+	// tracepoints (CAP_BPF, a ring-buffer-capable kernel, the "ebpf" build
+	// tag). A non-nil error means the caller should fall back to polling.
+	Available() error
+
+	// Monitor attaches the tracepoints and emits ProcessCreated/ProcessTerminated
+	// events until ctx is cancelled. onCreate is called with each newly
+	// observed process before its created event is emitted, so a
+	// poll-based collector can seed its snapshot and skip re-reporting it.
+	Monitor(ctx context.Context, onCreate func(types.ProcessInfo)) error
+}
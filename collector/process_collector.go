@@ -13,6 +13,7 @@ import (
 	"github.com/shirou/gopsutil/v3/process"
 
 	cfg "ArgusSentinel/config"
+	"ArgusSentinel/metrics"
 	"ArgusSentinel/types"
 	"ArgusSentinel/utils"
 )
@@ -25,9 +26,15 @@ type ProcessCollector struct {
 	// Add fields for Windows API handles
 	// and process tracking
 	currentProcesses map[int32]types.ProcessInfo
+	parent           map[int32]int32   // pid -> parent pid, rebuilt every poll
+	children         map[int32][]int32 // parent pid -> child pids, rebuilt every poll
 	events           chan<- types.ProcessEvent
 	config           *cfg.MonitoringConfig
 	filter           *cfg.ProcessFilter
+	cgroups          cgroupEnricher
+	ebpf             ebpfSource
+	connections      *ConnectionCollector
+	metrics          *metrics.Collector
 	mutex            sync.RWMutex
 }
 
@@ -42,10 +49,121 @@ func NewProcessCollector(events chan<- types.ProcessEvent, config *cfg.Monitorin
 		events:           events,
 		config:           config,
 		filter:           cfg.NewProcessFilter(config), // Initialize the filter
+		cgroups:          newCgroupEnricher(config),
+		ebpf:             newEbpfSource(events, config),
+		connections:      NewConnectionCollector(config),
 		mutex:            sync.RWMutex{},
 	}
 }
 
+/*
+* ProcessCollector SeedProcess method
+* Records info as already known for its pid, called by the eBPF source
+* when it observes a process exec/fork so the next poll snapshot treats
+* the pid as already-seen instead of emitting a duplicate ProcessCreated
+* event for it. The eBPF record only carries PID/ParentPID/Name, so this
+* fills in the rest via the normal gopsutil lookup before seeding -
+* otherwise the next poll would diff a fully-populated snapshot against
+* an almost-empty one and spuriously report every monitored field as
+* changed. Falls back to the partial record if the process has already
+* exited by the time we look it up.
+ */
+func (pc *ProcessCollector) SeedProcess(info types.ProcessInfo) {
+	seeded := info
+	if p, err := process.NewProcess(info.PID); err == nil {
+		if full, err := pc.getProcessInfo(p); err == nil {
+			seeded = full
+		}
+	}
+
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	pc.currentProcesses[seeded.PID] = seeded
+}
+
+/*
+* ProcessCollector Ancestors method
+* Returns pid's ancestor chain from the process tree built on the last
+* poll, ordered from the eldest ancestor down to pid's immediate parent.
+* Returns nil if pid or its lineage isn't currently known.
+ */
+func (pc *ProcessCollector) Ancestors(pid int32) []int32 {
+	pc.mutex.RLock()
+	defer pc.mutex.RUnlock()
+	return ancestorChain(pc.parent, pid)
+}
+
+/*
+* ProcessCollector Descendants method
+* Returns all descendants of pid (children, grandchildren, ...) from the
+* process tree built on the last poll, in breadth-first order.
+ */
+func (pc *ProcessCollector) Descendants(pid int32) []int32 {
+	pc.mutex.RLock()
+	defer pc.mutex.RUnlock()
+	return descendantsOf(pc.children, pid)
+}
+
+/*
+* buildProcessTree indexes a poll snapshot by parent/child relationship,
+* used both to answer Ancestors/Descendants and to attach the ancestor
+* chain to ProcessTreeSpawn events
+ */
+func buildProcessTree(snapshot map[int32]types.ProcessInfo) (parent map[int32]int32, children map[int32][]int32) {
+	parent = make(map[int32]int32, len(snapshot))
+	children = make(map[int32][]int32, len(snapshot))
+	for pid, info := range snapshot {
+		parent[pid] = info.ParentPID
+		children[info.ParentPID] = append(children[info.ParentPID], pid)
+	}
+	return parent, children
+}
+
+func ancestorChain(parent map[int32]int32, pid int32) []int32 {
+	var chain []int32
+	seen := map[int32]bool{pid: true}
+	for {
+		ppid, ok := parent[pid]
+		if !ok || ppid == 0 || seen[ppid] {
+			break
+		}
+		chain = append(chain, ppid)
+		seen[ppid] = true
+		pid = ppid
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+func descendantsOf(children map[int32][]int32, pid int32) []int32 {
+	var descendants []int32
+	seen := map[int32]bool{pid: true}
+	queue := append([]int32(nil), children[pid]...)
+	for len(queue) > 0 {
+		child := queue[0]
+		queue = queue[1:]
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		descendants = append(descendants, child)
+		queue = append(queue, children[child]...)
+	}
+	return descendants
+}
+
+/*
+* ProcessCollector SetMetrics method
+* Wires an optional metrics.Collector to record poll duration and
+* per-process gauges. A nil collector (the default) disables metrics.
+ */
+func (pc *ProcessCollector) SetMetrics(m *metrics.Collector) {
+	pc.metrics = m
+}
+
 /*
 * ProcessCollector getProcessInfo method
 * Extract information from a *process.Process and return it as types.ProcessInfo
@@ -70,6 +188,22 @@ func (pc *ProcessCollector) getProcessInfo(p *process.Process) (types.ProcessInf
 		info.MemoryUsage = memInfo.RSS // Resident Set Size
 	}
 
+	if pc.config.MonitorCgroups {
+		if enriched, err := pc.cgroups.Enrich(p.Pid, info); err != nil {
+			log.Printf("cgroup enrichment failed for pid %d: %v", p.Pid, err)
+		} else {
+			info = enriched
+		}
+	}
+
+	if pc.config.MonitorConnections {
+		if conns, err := pc.connections.Collect(p.Pid); err != nil {
+			log.Printf("connection collection failed for pid %d: %v", p.Pid, err)
+		} else {
+			info.NetworkConns = conns
+		}
+	}
+
 	return info, nil
 }
 
@@ -80,6 +214,10 @@ func (pc *ProcessCollector) getProcessInfo(p *process.Process) (types.ProcessInf
 func (pc *ProcessCollector) getRunningProcesses() (map[int32]types.ProcessInfo, error) {
 	processes := make(map[int32]types.ProcessInfo)
 
+	if pc.config.MonitorCgroups {
+		pc.cgroups.BeginPoll()
+	}
+
 	procs, err := process.Processes()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get processes: %v", err)
@@ -105,6 +243,25 @@ func (pc *ProcessCollector) getRunningProcesses() (map[int32]types.ProcessInfo,
 * Monitors processes, checks for newly created, terminated and modified processes
  */
 func (pc *ProcessCollector) Monitor(ctx context.Context) error {
+	var ebpfWg sync.WaitGroup
+	if pc.config.CollectorMode != "poll" {
+		if err := pc.ebpf.Available(); err != nil {
+			if pc.config.CollectorMode == "ebpf" {
+				return fmt.Errorf("ebpf collector unavailable: %w", err)
+			}
+			log.Printf("eBPF collector unavailable, falling back to polling: %v", err)
+		} else {
+			ebpfWg.Add(1)
+			go func() {
+				defer ebpfWg.Done()
+				if err := pc.ebpf.Monitor(ctx, pc.SeedProcess); err != nil {
+					log.Printf("eBPF collector error: %v", err)
+				}
+			}()
+		}
+	}
+	defer ebpfWg.Wait()
+
 	ticker := time.NewTicker(pc.config.PollInterval)
 	defer ticker.Stop()
 
@@ -113,13 +270,24 @@ func (pc *ProcessCollector) Monitor(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
+			pollStart := time.Now()
 			newSnapshot, err := pc.getRunningProcesses()
+			if pc.metrics != nil {
+				pc.metrics.ObservePollDuration(time.Since(pollStart))
+			}
 			if err != nil {
 				continue
 			}
 
+			if pc.metrics != nil {
+				pc.metrics.UpdateProcessGauges(newSnapshot)
+			}
+
 			pc.mutex.Lock()
 
+			parent, children := buildProcessTree(newSnapshot)
+			pc.parent, pc.children = parent, children
+
 			// Check for new processes
 			for pid, newInfo := range newSnapshot {
 				if oldInfo, exists := pc.currentProcesses[pid]; exists {
@@ -148,6 +316,12 @@ func (pc *ProcessCollector) Monitor(ctx context.Context) error {
 							Process:   newInfo,
 							// Add other event details
 						}
+						pc.events <- types.ProcessEvent{
+							Type:      types.ProcessTreeSpawn,
+							Timestamp: time.Now(),
+							Process:   newInfo,
+							Ancestors: ancestorChain(parent, pid),
+						}
 					}
 				}
 			}
@@ -229,6 +403,21 @@ func (pc *ProcessCollector) detectModifications(old, new types.ProcessInfo) []ty
 	var modifications []types.ProcessModification
 	timestamp := time.Now()
 
+	// Check re-parenting, e.g. orphaning or daemonization. Guarded on
+	// CreateTime matching so a pid recycled between polls - most likely
+	// precisely during the fork storms this is meant to catch - isn't
+	// mistaken for its previous occupant having been re-parented.
+	if pc.config.MonitorParentChanges && old.CreateTime == new.CreateTime && old.ParentPID != new.ParentPID {
+		modifications = append(modifications, types.ProcessModification{
+			Timestamp:   timestamp,
+			ProcessID:   new.PID,
+			ModType:     types.ParentChange,
+			OldValue:    old.ParentPID,
+			NewValue:    new.ParentPID,
+			Description: fmt.Sprintf("Process re-parented from PID %d to PID %d", old.ParentPID, new.ParentPID),
+		})
+	}
+
 	// Check command line changes
 	if pc.config.MonitorCommandLine && old.CommandLine != new.CommandLine {
 		modifications = append(modifications, types.ProcessModification{
@@ -296,5 +485,9 @@ func (pc *ProcessCollector) detectModifications(old, new types.ProcessInfo) []ty
 		})
 	}
 
+	if pc.config.MonitorConnections {
+		modifications = append(modifications, diffConnections(old.NetworkConns, new.NetworkConns)...)
+	}
+
 	return modifications
 }
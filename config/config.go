@@ -22,28 +22,68 @@ type MonitoringConfig struct {
 	HandleChangeThreshold int32   `yaml:"handleChangeThreshold"`
 
 	// Feature flags
-	MonitorCommandLine bool `yaml:"monitorCommandLine"`
-	MonitorMemory      bool `yaml:"monitorMemory"`
-	MonitorThreads     bool `yaml:"monitorThreads"`
-	MonitorHandles     bool `yaml:"monitorHandles"`
-	MonitorWorkingDir  bool `yaml:"monitorWorkingDir"`
-	MonitorConnections bool `yaml:"monitorConnections"`
+	MonitorCommandLine   bool `yaml:"monitorCommandLine"`
+	MonitorMemory        bool `yaml:"monitorMemory"`
+	MonitorThreads       bool `yaml:"monitorThreads"`
+	MonitorHandles       bool `yaml:"monitorHandles"`
+	MonitorWorkingDir    bool `yaml:"monitorWorkingDir"`
+	MonitorConnections   bool `yaml:"monitorConnections"`
+	MonitorCgroups       bool `yaml:"monitorCgroups"`
+	MonitorParentChanges bool `yaml:"monitorParentChanges"`
 
 	// Process filtering
 	ExcludedProcesses []string `yaml:"excludedProcesses"`
 	IncludedProcesses []string `yaml:"includedProcesses"`
 	ExcludedUsers     []string `yaml:"excludedUsers"`
 
+	// Cgroup filtering, glob patterns matched against a process' cgroup path
+	IncludedCgroups []string `yaml:"includedCgroups"`
+	ExcludedCgroups []string `yaml:"excludedCgroups"`
+
 	// Thresholds
 	ProcessPriorityThreshold int32    `yaml:"processPriorityThreshold"`
 	MaxProcessesToMonitor    int32    `yaml:"maxProcessesToMonitor"`
 	ProcessAgeThreshold      duration `yaml:"processAgeThreshold"`
+	MaxConnectionsPerProcess int32    `yaml:"maxConnectionsPerProcess"`
+
+	// Resolve remote connection IPs to hostnames via reverse DNS. Off by
+	// default since lookups can block on an unresponsive resolver.
+	ResolveConnectionHostnames bool `yaml:"resolveConnectionHostnames"`
+
+	// Event sinks
+	Outputs          []OutputConfig `yaml:"outputs"`
+	OutputBufferSize int            `yaml:"outputBufferSize"`
+
+	// Behavioral detection, empty DetectionRulesDir disables the engine
+	DetectionRulesDir string `yaml:"detectionRulesDir"`
+
+	// Prometheus metrics, empty MetricsAddress disables the /metrics server
+	MetricsAddress string `yaml:"metricsAddress"`
+	MetricsTopN    int    `yaml:"metricsTopN"` // per-process gauges are limited to the top N by CPU and by RSS
+
+	// Process event source: "poll" (default), "ebpf", or "auto" (prefer
+	// eBPF, falling back to polling when it's unavailable)
+	CollectorMode string `yaml:"collectorMode"`
 }
 
 type duration struct {
 	time.Duration
 }
 
+/*
+* OutputConfig struct
+* Describes one event sink: its type (stdout, file, syslog, webhook) and
+* sink-specific options. Multiple outputs can be configured to fan events
+* out to several destinations concurrently. Options is also where the
+* multiplexer-level "blocking" flag lives (see events.NewMultiplexer):
+* "true" makes this sink back-pressure the producer once its queue fills
+* instead of the default drop-on-full.
+ */
+type OutputConfig struct {
+	Type    string            `yaml:"type"`
+	Options map[string]string `yaml:"options"`
+}
+
 func LoadConfig(path string) (*MonitoringConfig, error) {
 	configFile, err := os.ReadFile(path)
 	if err != nil {
@@ -73,20 +113,38 @@ func DefaultConfig() *MonitoringConfig {
 		ThreadChangeThreshold: 2,    // Thread count change >= 2
 		HandleChangeThreshold: 10,   // Handle count change >= 10
 
-		MonitorCommandLine: true,
-		MonitorMemory:      true,
-		MonitorThreads:     true,
-		MonitorHandles:     true,
-		MonitorWorkingDir:  true,
-		MonitorConnections: false,
+		MonitorCommandLine:   true,
+		MonitorMemory:        true,
+		MonitorThreads:       true,
+		MonitorHandles:       true,
+		MonitorWorkingDir:    true,
+		MonitorConnections:   false,
+		MonitorCgroups:       false,
+		MonitorParentChanges: true,
 
 		ExcludedProcesses: []string{"svchost.exe", "RuntimeBroker.exe"},
 		IncludedProcesses: []string{},
 		ExcludedUsers:     []string{"root"},
 
+		IncludedCgroups: []string{},
+		ExcludedCgroups: []string{},
+
 		ProcessPriorityThreshold: 32768, // Normal priority
 		MaxProcessesToMonitor:    1000,
 		ProcessAgeThreshold:      duration{1 * time.Minute},
+		MaxConnectionsPerProcess: 100,
+
+		ResolveConnectionHostnames: false,
+
+		Outputs:          []OutputConfig{{Type: "stdout"}},
+		OutputBufferSize: 100,
+
+		DetectionRulesDir: "",
+
+		MetricsAddress: "",
+		MetricsTopN:    20,
+
+		CollectorMode: "poll",
 	}
 }
 func (c *MonitoringConfig) Validate() error {
@@ -110,5 +168,15 @@ func (c *MonitoringConfig) Validate() error {
 		return fmt.Errorf("handle change threshold must be non-negative")
 	}
 
+	if c.MaxConnectionsPerProcess < 0 {
+		return fmt.Errorf("max connections per process must be non-negative")
+	}
+
+	switch c.CollectorMode {
+	case "", "poll", "ebpf", "auto":
+	default:
+		return fmt.Errorf("collector mode must be one of poll, ebpf, auto")
+	}
+
 	return nil
 }
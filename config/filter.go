@@ -58,5 +58,32 @@ func (pf *ProcessFilter) ShouldMonitorProcess(info types.ProcessInfo) bool {
 	// 	return false
 	// }
 
+	if info.CgroupPath != "" && !pf.ShouldMonitorCgroup(info.CgroupPath) {
+		return false
+	}
+
+	return true
+}
+
+/*
+* ProcessFilter ShouldMonitorCgroup method
+* Applies IncludedCgroups/ExcludedCgroups glob patterns to a process' cgroup path
+ */
+func (pf *ProcessFilter) ShouldMonitorCgroup(cgroupPath string) bool {
+	for _, excluded := range pf.config.ExcludedCgroups {
+		if match, _ := filepath.Match(excluded, cgroupPath); match {
+			return false
+		}
+	}
+
+	if len(pf.config.IncludedCgroups) > 0 {
+		for _, included := range pf.config.IncludedCgroups {
+			if match, _ := filepath.Match(included, cgroupPath); match {
+				return true
+			}
+		}
+		return false
+	}
+
 	return true
 }
@@ -0,0 +1,241 @@
+// detect/engine.go
+package detect
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"ArgusSentinel/events"
+	"ArgusSentinel/types"
+)
+
+/*
+* Engine struct
+* Evaluates compiled rules against the ProcessEvent stream and emits a
+* types.DetectionEvent for each match, routed through the same sink
+* subsystem as regular process events. Rules hot-reload from rulesDir via
+* fsnotify.
+ */
+type Engine struct {
+	rulesDir string
+	sinks    *events.Multiplexer
+
+	mutex sync.RWMutex
+	index *ruleIndex
+
+	processIndex map[int32]types.ProcessInfo  // last known info per pid, for parent/child chain lookups
+	history      map[thresholdKey][]time.Time // sliding window counters, keyed by pid+rule
+
+	watcher *fsnotify.Watcher
+}
+
+type thresholdKey struct {
+	pid    int32
+	ruleID string
+}
+
+/*
+* Create a new Engine, loading and compiling every rule under rulesDir
+ */
+func NewEngine(rulesDir string, sinks *events.Multiplexer) (*Engine, error) {
+	rules, err := loadRulesFromDir(rulesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("detect: creating rule watcher: %w", err)
+	}
+	if err := watcher.Add(rulesDir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("detect: watching rules directory %q: %w", rulesDir, err)
+	}
+
+	return &Engine{
+		rulesDir:     rulesDir,
+		sinks:        sinks,
+		index:        buildIndex(rules),
+		processIndex: make(map[int32]types.ProcessInfo),
+		history:      make(map[thresholdKey][]time.Time),
+		watcher:      watcher,
+	}, nil
+}
+
+/*
+* Engine Start method
+* Launches the fsnotify hot-reload goroutine. Does not block.
+ */
+func (e *Engine) Start(ctx context.Context) {
+	go e.watchRules(ctx)
+}
+
+/*
+* Engine Stop method
+ */
+func (e *Engine) Stop() {
+	e.watcher.Close()
+}
+
+func (e *Engine) watchRules(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fsEvent, ok := <-e.watcher.Events:
+			if !ok {
+				return
+			}
+			if fsEvent.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+			e.reload()
+		case err, ok := <-e.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("detect: rule watcher error: %v", err)
+		}
+	}
+}
+
+func (e *Engine) reload() {
+	rules, err := loadRulesFromDir(e.rulesDir)
+	if err != nil {
+		log.Printf("detect: failed to reload rules from %s: %v", e.rulesDir, err)
+		return
+	}
+
+	index := buildIndex(rules)
+
+	e.mutex.Lock()
+	e.index = index
+	e.mutex.Unlock()
+
+	log.Printf("detect: reloaded %d rules from %s", len(rules), e.rulesDir)
+}
+
+/*
+* Engine HandleEvent method
+* Evaluates a single ProcessEvent against the compiled rule index and
+* emits a types.DetectionEvent through the sink subsystem for every match
+ */
+func (e *Engine) HandleEvent(event types.ProcessEvent) {
+	e.mutex.RLock()
+	index := e.index
+	e.mutex.RUnlock()
+
+	e.updateProcessIndex(event)
+
+	switch event.Type {
+	case types.ProcessCreated:
+		for _, rule := range index.fieldRules {
+			if rule.matchesProcess(event.Process) {
+				e.emit(rule, event, "field predicate matched")
+			}
+		}
+	case types.ProcessTreeSpawn:
+		// A ProcessCreated for the same process already ran the field
+		// rules above; this event exists to carry the ancestor chain, so
+		// it's the one that drives parent/child chain matching.
+		for _, rule := range index.chainRules {
+			if e.matchesChain(rule, event.Process) {
+				e.emit(rule, event, "parent/child chain matched")
+			}
+		}
+	case types.ProcessModified:
+		for _, rule := range index.fieldRules {
+			if rule.matchesProcess(event.Process) {
+				e.emit(rule, event, "field predicate matched")
+			}
+		}
+		for _, rule := range index.thresholdRules[event.ModType] {
+			if e.checkThreshold(rule, event) {
+				e.emit(rule, event, fmt.Sprintf("threshold of %d %s modifications reached", rule.Threshold.Count, rule.Threshold.ModType))
+			}
+		}
+	case types.ProcessTerminated:
+		delete(e.processIndex, event.Process.PID)
+	}
+}
+
+func (e *Engine) updateProcessIndex(event types.ProcessEvent) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if event.Type == types.ProcessTerminated {
+		delete(e.processIndex, event.Process.PID)
+		return
+	}
+	e.processIndex[event.Process.PID] = event.Process
+}
+
+/*
+* matchesChain walks up the process' ancestry comparing each hop against
+* rule.ParentChild, outermost ancestor first
+ */
+func (e *Engine) matchesChain(rule Rule, proc types.ProcessInfo) bool {
+	chain := rule.ParentChild
+	if len(chain) < 2 || !nameMatches(proc.Name, chain[len(chain)-1]) {
+		return false
+	}
+
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	current := proc
+	for i := len(chain) - 2; i >= 0; i-- {
+		parent, ok := e.processIndex[current.ParentPID]
+		if !ok || !nameMatches(parent.Name, chain[i]) {
+			return false
+		}
+		current = parent
+	}
+
+	return true
+}
+
+func nameMatches(name, pattern string) bool {
+	return strings.EqualFold(name, pattern)
+}
+
+/*
+* checkThreshold records this modification's timestamp and reports whether
+* the rule's count/window threshold has been reached for this process
+ */
+func (e *Engine) checkThreshold(rule Rule, event types.ProcessEvent) bool {
+	key := thresholdKey{pid: event.Process.PID, ruleID: rule.ID}
+	now := event.Timestamp
+	cutoff := now.Add(-rule.Threshold.Window)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	timestamps := append(e.history[key], now)
+	kept := timestamps[:0]
+	for _, ts := range timestamps {
+		if ts.After(cutoff) {
+			kept = append(kept, ts)
+		}
+	}
+	e.history[key] = kept
+
+	return len(kept) >= rule.Threshold.Count
+}
+
+func (e *Engine) emit(rule Rule, event types.ProcessEvent, description string) {
+	e.sinks.EmitDetection(types.DetectionEvent{
+		Timestamp:   event.Timestamp,
+		RuleID:      rule.ID,
+		Severity:    rule.Severity,
+		Process:     event.Process,
+		ModType:     event.ModType,
+		Description: fmt.Sprintf("%s: %s", rule.Description, description),
+	})
+}
@@ -0,0 +1,35 @@
+// detect/index.go
+package detect
+
+import "ArgusSentinel/types"
+
+/*
+* ruleIndex struct
+* Buckets compiled rules by the kind of event they react to, so evaluating
+* an event only touches the rules that could possibly match it instead of
+* scanning every loaded rule
+ */
+type ruleIndex struct {
+	fieldRules     []Rule
+	chainRules     []Rule
+	thresholdRules map[types.ModificationType][]Rule
+}
+
+func buildIndex(rules []Rule) *ruleIndex {
+	idx := &ruleIndex{
+		thresholdRules: make(map[types.ModificationType][]Rule),
+	}
+
+	for _, rule := range rules {
+		switch {
+		case rule.Threshold != nil:
+			idx.thresholdRules[rule.compiled.modType] = append(idx.thresholdRules[rule.compiled.modType], rule)
+		case rule.isChainRule():
+			idx.chainRules = append(idx.chainRules, rule)
+		case rule.isFieldRule():
+			idx.fieldRules = append(idx.fieldRules, rule)
+		}
+	}
+
+	return idx
+}
@@ -0,0 +1,67 @@
+// detect/loader.go
+package detect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ruleFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+/*
+* loadRuleFile parses and compiles every rule in a single YAML file
+ */
+func loadRuleFile(path string) ([]Rule, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("detect: reading rule file %q: %w", path, err)
+	}
+
+	var file ruleFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("detect: parsing rule file %q: %w", path, err)
+	}
+
+	for i := range file.Rules {
+		if err := file.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("detect: compiling rule %q in %q: %w", file.Rules[i].ID, path, err)
+		}
+	}
+
+	return file.Rules, nil
+}
+
+/*
+* loadRulesFromDir loads and compiles every *.yaml/*.yml rule file in dir
+ */
+func loadRulesFromDir(dir string) ([]Rule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("detect: reading rules directory %q: %w", dir, err)
+	}
+
+	var rules []Rule
+	for _, entry := range entries {
+		if entry.IsDir() || !isYAMLFile(entry.Name()) {
+			continue
+		}
+
+		fileRules, err := loadRuleFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	return rules, nil
+}
+
+func isYAMLFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,138 @@
+// detect/rule.go
+package detect
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"ArgusSentinel/types"
+)
+
+/*
+* Rule struct
+* A single behavioral detection rule, loaded from YAML. A rule matches in
+* one of three ways: field predicates against a process' identity,
+* a parent/child process chain, or a threshold of modifications within a
+* sliding window.
+ */
+type Rule struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Severity    string `yaml:"severity"`
+
+	NameRegex    string `yaml:"nameRegex"`
+	ExeRegex     string `yaml:"exeRegex"`
+	UserRegex    string `yaml:"userRegex"`
+	CmdlineRegex string `yaml:"cmdlineRegex"`
+
+	// ParentChild lists a process lineage, outermost ancestor first, e.g.
+	// ["winword.exe", "powershell.exe"] matches powershell.exe spawned
+	// (directly or transitively) by winword.exe.
+	ParentChild []string `yaml:"parentChain"`
+
+	Threshold *ThresholdRule `yaml:"threshold"`
+
+	compiled compiledRule
+}
+
+/*
+* ThresholdRule struct
+* Matches when a process accumulates at least Count modifications of
+* ModType within Window
+ */
+type ThresholdRule struct {
+	ModType string        `yaml:"modType"`
+	Count   int           `yaml:"count"`
+	Window  time.Duration `yaml:"window"`
+}
+
+/*
+* compiledRule struct
+* Pre-compiled form of a Rule's matchers, built once at load time
+ */
+type compiledRule struct {
+	nameRe    *regexp.Regexp
+	exeRe     *regexp.Regexp
+	userRe    *regexp.Regexp
+	cmdlineRe *regexp.Regexp
+	modType   types.ModificationType
+}
+
+var modTypeByName = map[string]types.ModificationType{
+	"memory":           types.MemoryModification,
+	"threadCreate":     types.ThreadCreation,
+	"handleTable":      types.HandleTableChange,
+	"privilege":        types.PrivilegeChange,
+	"behavior":         types.BehaviorChange,
+	"commandLine":      types.CommandLineChange,
+	"threadCount":      types.ThreadCountChange,
+	"handleCount":      types.HandleCountChange,
+	"workingDir":       types.WorkingDirectoryChange,
+	"parentChange":     types.ParentChange,
+	"connectionOpened": types.ConnectionOpened,
+	"connectionClosed": types.ConnectionClosed,
+}
+
+/*
+* compile builds the compiledRule for a Rule, validating its regexes and
+* threshold modType up front so evaluation never has to handle bad input
+ */
+func (r *Rule) compile() error {
+	var err error
+
+	if r.NameRegex != "" {
+		if r.compiled.nameRe, err = regexp.Compile(r.NameRegex); err != nil {
+			return fmt.Errorf("nameRegex: %w", err)
+		}
+	}
+	if r.ExeRegex != "" {
+		if r.compiled.exeRe, err = regexp.Compile(r.ExeRegex); err != nil {
+			return fmt.Errorf("exeRegex: %w", err)
+		}
+	}
+	if r.UserRegex != "" {
+		if r.compiled.userRe, err = regexp.Compile(r.UserRegex); err != nil {
+			return fmt.Errorf("userRegex: %w", err)
+		}
+	}
+	if r.CmdlineRegex != "" {
+		if r.compiled.cmdlineRe, err = regexp.Compile(r.CmdlineRegex); err != nil {
+			return fmt.Errorf("cmdlineRegex: %w", err)
+		}
+	}
+
+	if r.Threshold != nil {
+		modType, ok := modTypeByName[r.Threshold.ModType]
+		if !ok {
+			return fmt.Errorf("threshold: unknown modType %q", r.Threshold.ModType)
+		}
+		r.compiled.modType = modType
+	}
+
+	return nil
+}
+
+func (r *Rule) isFieldRule() bool {
+	return r.compiled.nameRe != nil || r.compiled.exeRe != nil || r.compiled.userRe != nil || r.compiled.cmdlineRe != nil
+}
+
+func (r *Rule) isChainRule() bool {
+	return len(r.ParentChild) >= 2
+}
+
+func (r *Rule) matchesProcess(info types.ProcessInfo) bool {
+	if r.compiled.nameRe != nil && !r.compiled.nameRe.MatchString(info.Name) {
+		return false
+	}
+	if r.compiled.exeRe != nil && !r.compiled.exeRe.MatchString(info.Executable) {
+		return false
+	}
+	if r.compiled.userRe != nil && !r.compiled.userRe.MatchString(info.Username) {
+		return false
+	}
+	if r.compiled.cmdlineRe != nil && !r.compiled.cmdlineRe.MatchString(info.CommandLine) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+// events/file.go
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"ArgusSentinel/types"
+)
+
+const defaultMaxFileSizeMB = 100
+
+/*
+* FileSink struct
+* Appends NDJSON-encoded events to a file, rotating it aside with a
+* timestamp suffix once it crosses maxSizeMB
+ */
+type FileSink struct {
+	path      string
+	maxSizeMB int64
+	mutex     sync.Mutex
+	file      *os.File
+	size      int64
+}
+
+/*
+* Create a new FileSink from sink options
+* Supported options: "path" (required), "maxSizeMB" (default 100)
+ */
+func NewFileSink(options map[string]string) (*FileSink, error) {
+	path := options["path"]
+	if path == "" {
+		return nil, fmt.Errorf("events: file sink requires a \"path\" option")
+	}
+
+	maxSizeMB := int64(defaultMaxFileSizeMB)
+	if raw, ok := options["maxSizeMB"]; ok {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("events: invalid maxSizeMB %q: %w", raw, err)
+		}
+		maxSizeMB = parsed
+	}
+
+	sink := &FileSink{
+		path:      path,
+		maxSizeMB: maxSizeMB,
+	}
+
+	if err := sink.openCurrent(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+func (s *FileSink) openCurrent() error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("events: opening file sink %q: %w", s.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("events: statting file sink %q: %w", s.path, err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("events: closing file sink %q before rotation: %w", s.path, err)
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", s.path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(s.path, rotatedPath); err != nil {
+		return fmt.Errorf("events: rotating file sink %q: %w", s.path, err)
+	}
+
+	return s.openCurrent()
+}
+
+func (s *FileSink) Emit(event types.ProcessEvent) error {
+	return s.writeJSON(event)
+}
+
+func (s *FileSink) EmitDetection(event types.DetectionEvent) error {
+	return s.writeJSON(event)
+}
+
+func (s *FileSink) writeJSON(v interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	line, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("events: marshaling event: %w", err)
+	}
+	line = append(line, '\n')
+
+	if s.maxSizeMB > 0 && s.size+int64(len(line)) > s.maxSizeMB*1024*1024 {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	if err != nil {
+		return fmt.Errorf("events: writing to file sink %q: %w", s.path, err)
+	}
+	s.size += int64(n)
+
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,162 @@
+// events/sink.go
+package events
+
+import (
+	"fmt"
+	"log"
+
+	"ArgusSentinel/config"
+	"ArgusSentinel/types"
+)
+
+/*
+* Sink interface
+* Destination-agnostic output for process and detection events (stdout,
+* file, syslog, webhook, ...)
+ */
+type Sink interface {
+	Emit(event types.ProcessEvent) error
+	EmitDetection(event types.DetectionEvent) error
+	Close() error
+}
+
+/*
+* NewSink factory function
+* Builds the Sink described by a config.OutputConfig
+ */
+func NewSink(cfg config.OutputConfig) (Sink, error) {
+	switch cfg.Type {
+	case "", "stdout":
+		return NewStdoutSink(), nil
+	case "file":
+		return NewFileSink(cfg.Options)
+	case "syslog":
+		return NewSyslogSink(cfg.Options)
+	case "webhook":
+		return NewWebhookSink(cfg.Options)
+	default:
+		return nil, fmt.Errorf("events: unknown sink type %q", cfg.Type)
+	}
+}
+
+/*
+* lane struct
+* Pairs a Sink with its own buffered queue and consumer goroutine
+ */
+type lane struct {
+	name     string
+	sink     Sink
+	queue    chan interface{} // types.ProcessEvent or types.DetectionEvent
+	done     chan struct{}
+	blocking bool // if set, a full queue back-pressures Emit/EmitDetection instead of dropping
+}
+
+/*
+* Multiplexer struct
+* Fans events out to every configured sink concurrently. Each sink gets its
+* own buffered lane, and by default a slow or stuck sink can't stall the
+* others - once a lane's queue is full, further events for that sink are
+* dropped (and logged) rather than blocking the producer or any other
+* lane. A sink opted into "blocking" mode (see NewMultiplexer) trades that
+* isolation for real back-pressure: once its lane fills, Emit/EmitDetection
+* block until it drains, which stalls the producer (and so every other
+* lane behind it in that call) until the slow sink catches up.
+ */
+type Multiplexer struct {
+	lanes []*lane
+}
+
+/*
+* Create a new Multiplexer from a set of named sinks. blocking marks which
+* sink names (the same keys as sinks) should back-pressure the producer
+* instead of dropping events once their lane is full; sinks absent from it
+* default to drop-on-full. A nil blocking map makes every lane drop-on-full.
+ */
+func NewMultiplexer(sinks map[string]Sink, bufferSize int, blocking map[string]bool) *Multiplexer {
+	if bufferSize <= 0 {
+		bufferSize = 100
+	}
+
+	m := &Multiplexer{}
+	for name, sink := range sinks {
+		l := &lane{
+			name:     name,
+			sink:     sink,
+			queue:    make(chan interface{}, bufferSize),
+			done:     make(chan struct{}),
+			blocking: blocking[name],
+		}
+		go l.run()
+		m.lanes = append(m.lanes, l)
+	}
+
+	return m
+}
+
+func (l *lane) run() {
+	defer close(l.done)
+	for item := range l.queue {
+		var err error
+		switch event := item.(type) {
+		case types.ProcessEvent:
+			err = l.sink.Emit(event)
+		case types.DetectionEvent:
+			err = l.sink.EmitDetection(event)
+		}
+		if err != nil {
+			log.Printf("events: sink %q failed to emit event: %v", l.name, err)
+		}
+	}
+}
+
+/*
+* Multiplexer Emit method
+* Queues the event on every lane. A drop-on-full lane discards the event
+* for that lane rather than blocking the other sinks; a blocking lane
+* waits for room instead, see NewMultiplexer.
+ */
+func (m *Multiplexer) Emit(event types.ProcessEvent) {
+	m.enqueue(event)
+}
+
+/*
+* Multiplexer EmitDetection method
+* Queues a detection event on every lane, same per-lane rules as Emit
+ */
+func (m *Multiplexer) EmitDetection(event types.DetectionEvent) {
+	m.enqueue(event)
+}
+
+func (m *Multiplexer) enqueue(item interface{}) {
+	for _, l := range m.lanes {
+		if l.blocking {
+			l.queue <- item
+			continue
+		}
+
+		select {
+		case l.queue <- item:
+		default:
+			log.Printf("events: sink %q queue full, dropping event", l.name)
+		}
+	}
+}
+
+/*
+* Multiplexer Close method
+* Drains every lane and closes its underlying sink
+ */
+func (m *Multiplexer) Close() error {
+	for _, l := range m.lanes {
+		close(l.queue)
+	}
+
+	for _, l := range m.lanes {
+		<-l.done
+		if err := l.sink.Close(); err != nil {
+			log.Printf("events: sink %q failed to close: %v", l.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// events/stdout.go
+package events
+
+import (
+	"fmt"
+	"log"
+
+	"ArgusSentinel/types"
+)
+
+/*
+* StdoutSink struct
+* Prints events the same way the monitor always has. Used as the default
+* sink when no outputs are configured.
+ */
+type StdoutSink struct{}
+
+/*
+* Create a new StdoutSink
+ */
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{}
+}
+
+func (s *StdoutSink) Emit(event types.ProcessEvent) error {
+	switch event.Type {
+	case types.ProcessCreated:
+		log.Println(fmt.Sprintf("[+] New process: PID=%d Name=%s User=%s",
+			event.Process.PID, event.Process.Name, event.Process.Username))
+	case types.ProcessTerminated:
+		log.Println(fmt.Sprintf("[-] Process terminated: PID=%d Name=%s",
+			event.Process.PID, event.Process.Name))
+	case types.ProcessModified:
+		log.Println(fmt.Sprintf("[***] Process modified: PID=%d Name:%s - %s\n",
+			event.Process.PID, event.Process.Name, event.Description))
+	case types.ProcessTreeSpawn:
+		log.Println(fmt.Sprintf("[+] Process tree spawn: PID=%d Name=%s Ancestors=%v",
+			event.Process.PID, event.Process.Name, event.Ancestors))
+	}
+
+	return nil
+}
+
+func (s *StdoutSink) EmitDetection(event types.DetectionEvent) error {
+	log.Println(fmt.Sprintf("[!] Detection: rule=%s severity=%s PID=%d Name=%s - %s",
+		event.RuleID, event.Severity, event.Process.PID, event.Process.Name, event.Description))
+	return nil
+}
+
+func (s *StdoutSink) Close() error {
+	return nil
+}
@@ -0,0 +1,141 @@
+// events/syslog.go
+package events
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"ArgusSentinel/types"
+)
+
+const (
+	syslogFacilityUser = 1 // RFC5424 facility: user-level messages
+	syslogAppName      = "argus-sentinel"
+)
+
+var syslogSeverityByEventType = map[types.EventType]int{
+	types.ProcessCreated:    6, // informational
+	types.ProcessTerminated: 6, // informational
+	types.ProcessModified:   4, // warning
+	types.ProcessTreeSpawn:  6, // informational
+}
+
+/*
+* SyslogSink struct
+* Formats events as RFC5424 syslog messages and writes them to a remote
+* syslog collector over UDP or TCP
+ */
+type SyslogSink struct {
+	conn     net.Conn
+	hostname string
+}
+
+/*
+* Create a new SyslogSink from sink options
+* Supported options: "address" (required, host:port), "network" (default "udp")
+ */
+func NewSyslogSink(options map[string]string) (*SyslogSink, error) {
+	address := options["address"]
+	if address == "" {
+		return nil, fmt.Errorf("events: syslog sink requires an \"address\" option")
+	}
+
+	network := options["network"]
+	if network == "" {
+		network = "udp"
+	}
+
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("events: dialing syslog at %q: %w", address, err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	return &SyslogSink{conn: conn, hostname: hostname}, nil
+}
+
+func (s *SyslogSink) Emit(event types.ProcessEvent) error {
+	severity, ok := syslogSeverityByEventType[event.Type]
+	if !ok {
+		severity = 6
+	}
+	priority := syslogFacilityUser*8 + severity
+
+	message := fmt.Sprintf("%s PID=%d Name=%s %s",
+		syslogEventLabel(event.Type), event.Process.PID, event.Process.Name, event.Description)
+
+	formatted := fmt.Sprintf("<%d>1 %s %s %s - - - %s\n",
+		priority,
+		event.Timestamp.UTC().Format(time.RFC3339),
+		s.hostname,
+		syslogAppName,
+		message)
+
+	_, err := s.conn.Write([]byte(formatted))
+	if err != nil {
+		return fmt.Errorf("events: writing to syslog: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) EmitDetection(event types.DetectionEvent) error {
+	priority := syslogFacilityUser*8 + syslogSeverityByDetectionSeverity(event.Severity)
+
+	message := fmt.Sprintf("detection rule=%s PID=%d Name=%s %s",
+		event.RuleID, event.Process.PID, event.Process.Name, event.Description)
+
+	formatted := fmt.Sprintf("<%d>1 %s %s %s - - - %s\n",
+		priority,
+		event.Timestamp.UTC().Format(time.RFC3339),
+		s.hostname,
+		syslogAppName,
+		message)
+
+	if _, err := s.conn.Write([]byte(formatted)); err != nil {
+		return fmt.Errorf("events: writing detection to syslog: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	return s.conn.Close()
+}
+
+func syslogSeverityByDetectionSeverity(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 2
+	case "high":
+		return 3
+	case "medium":
+		return 4
+	case "low":
+		return 6
+	default:
+		return 5
+	}
+}
+
+func syslogEventLabel(t types.EventType) string {
+	switch t {
+	case types.ProcessCreated:
+		return "process_created"
+	case types.ProcessTerminated:
+		return "process_terminated"
+	case types.ProcessModified:
+		return "process_modified"
+	case types.ProcessTreeSpawn:
+		return "process_tree_spawn"
+	default:
+		return "unknown"
+	}
+}
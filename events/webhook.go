@@ -0,0 +1,70 @@
+// events/webhook.go
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ArgusSentinel/types"
+)
+
+const defaultWebhookTimeout = 5 * time.Second
+
+/*
+* WebhookSink struct
+* POSTs each event as a JSON body to a configured URL
+ */
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+/*
+* Create a new WebhookSink from sink options
+* Supported options: "url" (required)
+ */
+func NewWebhookSink(options map[string]string) (*WebhookSink, error) {
+	url := options["url"]
+	if url == "" {
+		return nil, fmt.Errorf("events: webhook sink requires a \"url\" option")
+	}
+
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+	}, nil
+}
+
+func (s *WebhookSink) Emit(event types.ProcessEvent) error {
+	return s.post(event)
+}
+
+func (s *WebhookSink) EmitDetection(event types.DetectionEvent) error {
+	return s.post(event)
+}
+
+func (s *WebhookSink) post(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("events: marshaling event: %w", err)
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("events: posting to webhook %q: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("events: webhook %q returned status %d", s.url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *WebhookSink) Close() error {
+	return nil
+}
@@ -6,11 +6,15 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"time"
 
 	"ArgusSentinel/collector"
 	"ArgusSentinel/config"
+	"ArgusSentinel/detect"
+	"ArgusSentinel/events"
+	"ArgusSentinel/metrics"
 	"ArgusSentinel/types"
 
 	"github.com/shirou/gopsutil/v3/process"
@@ -21,11 +25,14 @@ import (
 * Main controller
  */
 type ProcessMonitor struct {
-	config *config.MonitoringConfig
-	ctx    context.Context
-	cancel context.CancelFunc
-	events chan types.ProcessEvent
-	wg     sync.WaitGroup
+	config           *config.MonitoringConfig
+	ctx              context.Context
+	cancel           context.CancelFunc
+	events           chan types.ProcessEvent
+	sinks            *events.Multiplexer
+	detector         *detect.Engine
+	metricsCollector *metrics.Collector
+	wg               sync.WaitGroup
 }
 
 /*
@@ -46,8 +53,36 @@ func NewProcessMonitor(config *config.MonitoringConfig) *ProcessMonitor {
 * Start monitoring processes
  */
 func (pm *ProcessMonitor) Start() error {
+	sinks, blocking, err := buildSinks(pm.config)
+	if err != nil {
+		return fmt.Errorf("building event sinks: %w", err)
+	}
+	pm.sinks = events.NewMultiplexer(sinks, pm.config.OutputBufferSize, blocking)
+
+	if pm.config.DetectionRulesDir != "" {
+		detector, err := detect.NewEngine(pm.config.DetectionRulesDir, pm.sinks)
+		if err != nil {
+			return fmt.Errorf("starting detection engine: %w", err)
+		}
+		detector.Start(pm.ctx)
+		pm.detector = detector
+	}
+
+	if pm.config.MetricsAddress != "" {
+		pm.metricsCollector = metrics.NewCollector(pm.config.MetricsTopN)
+
+		pm.wg.Add(1)
+		go func() {
+			defer pm.wg.Done()
+			if err := pm.metricsCollector.Serve(pm.ctx, pm.config.MetricsAddress); err != nil {
+				log.Printf("Metrics server error: %v", err)
+			}
+		}()
+	}
+
 	// Start monitoring goroutines
 	collector := collector.NewProcessCollector(pm.events, pm.config)
+	collector.SetMetrics(pm.metricsCollector)
 
 	pm.wg.Add(1)
 	go func() {
@@ -70,12 +105,50 @@ func (pm *ProcessMonitor) Start() error {
 func (pm *ProcessMonitor) Stop() {
 	pm.cancel()
 	pm.wg.Wait()
+	if pm.detector != nil {
+		pm.detector.Stop()
+	}
+	if pm.sinks != nil {
+		pm.sinks.Close()
+	}
+}
+
+/*
+* buildSinks helper function
+* Builds the configured event.Sink set, defaulting to stdout when no
+* outputs are configured. blocking carries the same keys as the returned
+* sinks map, marking which ones opted into back-pressure via the
+* "blocking" option (see events.NewMultiplexer).
+ */
+func buildSinks(cfg *config.MonitoringConfig) (sinks map[string]events.Sink, blocking map[string]bool, err error) {
+	outputs := cfg.Outputs
+	if len(outputs) == 0 {
+		outputs = []config.OutputConfig{{Type: "stdout"}}
+	}
+
+	sinks = make(map[string]events.Sink, len(outputs))
+	blocking = make(map[string]bool, len(outputs))
+	for i, outputCfg := range outputs {
+		sink, err := events.NewSink(outputCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("building sink %d (%s): %w", i, outputCfg.Type, err)
+		}
+
+		name := outputCfg.Type
+		if name == "" {
+			name = "stdout"
+		}
+		key := fmt.Sprintf("%s-%d", name, i)
+		sinks[key] = sink
+		blocking[key], _ = strconv.ParseBool(outputCfg.Options["blocking"])
+	}
+
+	return sinks, blocking, nil
 }
 
 /*
 * ProcessMonitor processEventLoop method
-* Poll events and output the processes found (Created/Terminated/Modified)
-* TODO: Improve output formatting
+* Poll events from the collector and fan them out to the configured sinks
  */
 func (pm *ProcessMonitor) processEventLoop() {
 	defer pm.wg.Done()
@@ -85,38 +158,17 @@ func (pm *ProcessMonitor) processEventLoop() {
 		case <-pm.ctx.Done():
 			return
 		case event := <-pm.events:
-			switch event.Type {
-			case types.ProcessCreated:
-				message := fmt.Sprintf("[+] New process: PID=%d Name=%s User=%s",
-					event.Process.PID,
-					event.Process.Name,
-					event.Process.Username)
-				log.Println(message)
-			case types.ProcessTerminated:
-				message := fmt.Sprintf("[-] Process terminated: PID=%d Name=%s",
-					event.Process.PID,
-					event.Process.Name)
-				log.Println(message)
-			case types.ProcessModified:
-				message := fmt.Sprintf("[***] Process modified: PID=%d Name:%s - %s\n",
-					event.Process.PID,
-					event.Process.Name,
-					event.Description)
-				log.Println(message)
+			pm.sinks.Emit(event)
+			if pm.detector != nil {
+				pm.detector.HandleEvent(event)
+			}
+			if pm.metricsCollector != nil {
+				pm.metricsCollector.RecordEvent(event)
 			}
 		}
 	}
 }
 
-/*
-* ProcessMonitor collectMetrics method
-* TODO: Implement metrics collection for processes and events
- */
-func (pm *ProcessMonitor) collectMetrics() {
-	defer pm.wg.Done()
-	// Implement collection
-}
-
 // don't know
 func (pm *ProcessMonitor) watchProcessEvents() error {
 	// Start simple polling approach
@@ -0,0 +1,204 @@
+// metrics/collector.go
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ArgusSentinel/types"
+)
+
+/*
+* Collector struct
+* Wraps the Prometheus metrics exported by the monitor. All fields are
+* safe for concurrent use, matching the prometheus client's own guarantees.
+ */
+type Collector struct {
+	registry *prometheus.Registry
+
+	processesMonitored prometheus.Gauge
+	eventsTotal        *prometheus.CounterVec
+	modificationsTotal *prometheus.CounterVec
+	pollDuration       prometheus.Histogram
+	processCPUPercent  *prometheus.GaugeVec
+	processRSSBytes    *prometheus.GaugeVec
+	cgroupCPUPercent   *prometheus.GaugeVec
+
+	topN int
+}
+
+/*
+* Create a new Collector and register its metrics on a fresh registry
+ */
+func NewCollector(topN int) *Collector {
+	if topN <= 0 {
+		topN = 20
+	}
+
+	registry := prometheus.NewRegistry()
+
+	c := &Collector{
+		registry: registry,
+		topN:     topN,
+
+		processesMonitored: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "argus_processes_monitored",
+			Help: "Number of processes currently being monitored.",
+		}),
+		eventsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "argus_events_total",
+			Help: "Total number of process events emitted, by type.",
+		}, []string{"type"}),
+		modificationsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "argus_modifications_total",
+			Help: "Total number of process modifications detected, by modification type.",
+		}, []string{"modtype"}),
+		pollDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "argus_poll_duration_seconds",
+			Help:    "Time taken to collect one snapshot of running processes.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		processCPUPercent: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "argus_process_cpu_percent",
+			Help: "CPU percent of the top-N monitored processes by CPU usage.",
+		}, []string{"pid", "name"}),
+		processRSSBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "argus_process_rss_bytes",
+			Help: "Resident set size of the top-N monitored processes by RSS.",
+		}, []string{"pid", "name"}),
+		cgroupCPUPercent: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "argus_cgroup_cpu_percent",
+			Help: "CPU percent of the top-N monitored cgroups by CPU usage.",
+		}, []string{"cgroup", "container_id"}),
+	}
+
+	registry.MustRegister(
+		c.processesMonitored,
+		c.eventsTotal,
+		c.modificationsTotal,
+		c.pollDuration,
+		c.processCPUPercent,
+		c.processRSSBytes,
+		c.cgroupCPUPercent,
+	)
+
+	return c
+}
+
+func (c *Collector) Registry() *prometheus.Registry {
+	return c.registry
+}
+
+/*
+* RecordEvent increments the events-by-type counter and, for modification
+* events, the modifications-by-modtype counter
+ */
+func (c *Collector) RecordEvent(event types.ProcessEvent) {
+	c.eventsTotal.WithLabelValues(eventTypeLabel(event.Type)).Inc()
+	if event.Type == types.ProcessModified {
+		c.modificationsTotal.WithLabelValues(event.ModType.String()).Inc()
+	}
+}
+
+/*
+* ObservePollDuration records how long one poll of the process table took
+ */
+func (c *Collector) ObservePollDuration(d time.Duration) {
+	c.pollDuration.Observe(d.Seconds())
+}
+
+/*
+* UpdateProcessGauges refreshes the per-process gauges from a poll
+* snapshot. To bound label cardinality on hosts with thousands of
+* processes, only the top-N processes by CPU and the top-N by RSS are
+* exported; everything else is dropped from the gauge set.
+ */
+func (c *Collector) UpdateProcessGauges(processes map[int32]types.ProcessInfo) {
+	c.processesMonitored.Set(float64(len(processes)))
+
+	all := make([]types.ProcessInfo, 0, len(processes))
+	for _, info := range processes {
+		all = append(all, info)
+	}
+
+	c.processCPUPercent.Reset()
+	for _, info := range topByCPU(all, c.topN) {
+		c.processCPUPercent.WithLabelValues(pidLabel(info.PID), info.Name).Set(info.CPUPercent)
+	}
+
+	c.processRSSBytes.Reset()
+	for _, info := range topByRSS(all, c.topN) {
+		c.processRSSBytes.WithLabelValues(pidLabel(info.PID), info.Name).Set(float64(info.MemoryUsage))
+	}
+
+	c.cgroupCPUPercent.Reset()
+	for _, info := range topByCgroupCPU(distinctCgroups(all), c.topN) {
+		c.cgroupCPUPercent.WithLabelValues(info.CgroupPath, info.ContainerID).Set(info.CgroupCPUPercent)
+	}
+}
+
+// distinctCgroups collapses processes down to one ProcessInfo per
+// CgroupPath, since every process in a cgroup reports the same
+// cgroup-level stats. Processes with no resolved cgroup are dropped.
+func distinctCgroups(processes []types.ProcessInfo) []types.ProcessInfo {
+	seen := make(map[string]types.ProcessInfo)
+	for _, info := range processes {
+		if info.CgroupPath == "" {
+			continue
+		}
+		seen[info.CgroupPath] = info
+	}
+
+	distinct := make([]types.ProcessInfo, 0, len(seen))
+	for _, info := range seen {
+		distinct = append(distinct, info)
+	}
+	return distinct
+}
+
+func topByCPU(processes []types.ProcessInfo, n int) []types.ProcessInfo {
+	sorted := append([]types.ProcessInfo(nil), processes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].CPUPercent > sorted[j].CPUPercent })
+	return firstN(sorted, n)
+}
+
+func topByRSS(processes []types.ProcessInfo, n int) []types.ProcessInfo {
+	sorted := append([]types.ProcessInfo(nil), processes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MemoryUsage > sorted[j].MemoryUsage })
+	return firstN(sorted, n)
+}
+
+func topByCgroupCPU(cgroups []types.ProcessInfo, n int) []types.ProcessInfo {
+	sorted := append([]types.ProcessInfo(nil), cgroups...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].CgroupCPUPercent > sorted[j].CgroupCPUPercent })
+	return firstN(sorted, n)
+}
+
+func firstN(processes []types.ProcessInfo, n int) []types.ProcessInfo {
+	if len(processes) < n {
+		return processes
+	}
+	return processes[:n]
+}
+
+func eventTypeLabel(t types.EventType) string {
+	switch t {
+	case types.ProcessCreated:
+		return "created"
+	case types.ProcessTerminated:
+		return "terminated"
+	case types.ProcessModified:
+		return "modified"
+	case types.ProcessTreeSpawn:
+		return "treeSpawn"
+	default:
+		return "unknown"
+	}
+}
+
+func pidLabel(pid int32) string {
+	return strconv.Itoa(int(pid))
+}
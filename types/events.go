@@ -14,6 +14,7 @@ const (
 	ProcessCreated EventType = iota
 	ProcessTerminated
 	ProcessModified
+	ProcessTreeSpawn // new process, carrying its full ancestor chain
 )
 
 /*
@@ -37,6 +38,20 @@ type ProcessInfo struct {
 	MemoryRegions []MemoryRegion
 	Privileges    []string
 	NetworkConns  []NetworkConnection
+
+	// Cgroup/container attribution, populated by collector.CgroupCollector
+	CgroupPath       string
+	ContainerID      string
+	CPUUser          uint64  // cgroup cpuacct user time, in nanoseconds
+	CPUSys           uint64  // cgroup cpuacct system time, in nanoseconds
+	CgroupCPUPercent float64 // (CPUUser+CPUSys) delta as a percent of wall-clock time since the last sample
+	RSS              uint64  // cgroup resident memory, in bytes
+	Cache            uint64  // cgroup page cache memory, in bytes
+	PgMajFault       uint64  // cgroup major page faults
+	SwapBytes        uint64  // cgroup swap usage, in bytes
+	IOReadBytes      uint64  // cgroup cumulative bytes read from block devices
+	IOWriteBytes     uint64  // cgroup cumulative bytes written to block devices
+	PidsCurrent      uint64  // number of tasks currently in the cgroup (pids controller)
 }
 
 /*
@@ -50,6 +65,7 @@ type ProcessEvent struct {
 	Process     ProcessInfo
 	ModType     ModificationType // Only used when Type is ProcessModified
 	Description string
+	Ancestors   []int32 // Only used when Type is ProcessTreeSpawn, ordered eldest-first
 }
 
 /*
@@ -86,8 +102,37 @@ const (
 	ThreadCountChange
 	HandleCountChange
 	WorkingDirectoryChange
+	ParentChange     // process' ParentPID changed while it stayed alive, e.g. re-parenting/orphaning
+	ConnectionOpened // process opened a new network connection
+	ConnectionClosed // a previously open network connection is gone
 )
 
+var modificationTypeNames = map[ModificationType]string{
+	MemoryModification:     "memory",
+	ThreadCreation:         "threadCreate",
+	HandleTableChange:      "handleTable",
+	PrivilegeChange:        "privilege",
+	BehaviorChange:         "behavior",
+	CommandLineChange:      "commandLine",
+	ThreadCountChange:      "threadCount",
+	HandleCountChange:      "handleCount",
+	WorkingDirectoryChange: "workingDir",
+	ParentChange:           "parentChange",
+	ConnectionOpened:       "connectionOpened",
+	ConnectionClosed:       "connectionClosed",
+}
+
+/*
+* ModificationType String method
+* Short label used in metrics and log output
+ */
+func (m ModificationType) String() string {
+	if name, ok := modificationTypeNames[m]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 /*
 * MemoryRegion struct
 * Currently not used
@@ -103,19 +148,35 @@ type MemoryRegion struct {
 	Usage       string // Description of usage (heap, stack, etc)
 }
 
+/*
+* DetectionEvent struct
+* Emitted by the detect package when a behavioral rule matches a
+* ProcessEvent/ProcessModification sequence. Routed through the same sink
+* subsystem as ProcessEvent.
+ */
+type DetectionEvent struct {
+	Timestamp   time.Time
+	RuleID      string
+	Severity    string
+	Process     ProcessInfo
+	ModType     ModificationType // only meaningful for threshold-based rules
+	Description string
+}
+
 /*
 * NetworkConnection struct
-* Currently not used
-* Represents information related to a process' network connection
-* TODO: Implement process network communication analysis
+* Represents a single open socket belonging to a process, populated by
+* collector.ConnectionCollector
  */
 type NetworkConnection struct {
 	LocalIP    string
 	LocalPort  uint16
 	RemoteIP   string
 	RemotePort uint16
+	RemoteHost string // reverse-DNS name of RemoteIP, empty unless ResolveConnectionHostnames is set and the lookup succeeded
 	Status     string // ESTABLISHED, LISTENING, etc
 	Protocol   string // TCP, UDP
+	Username   string // owner of the socket, resolved from its uid
 	ProcessID  int32
 	CreateTime time.Time
 }